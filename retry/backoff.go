@@ -0,0 +1,86 @@
+package retry
+
+import (
+	"math"
+	"time"
+)
+
+// BackoffStrategy computes the delay to wait before the next retry attempt.
+// When Config.Backoff is set, Do and DoWithData call NextDelay instead of
+// applying the MaxDelay/Factor/Jitter fields (Delay is only passed in as the
+// first lastDelay), so a strategy is free to implement its own jitter (e.g.
+// decorrelated jitter) or to honor a server-provided hint carried on err.
+type BackoffStrategy interface {
+	// NextDelay returns the delay before the next attempt, given the attempt
+	// number that just failed (1-based), the delay NextDelay returned for the
+	// previous attempt (Config.Delay when attempt is 1), and the error it
+	// returned.
+	NextDelay(attempt int, lastDelay time.Duration, err error) time.Duration
+}
+
+// constantBackoff always waits the same delay between attempts.
+type constantBackoff struct {
+	delay time.Duration
+}
+
+// ConstantBackoff returns a BackoffStrategy that waits d between every attempt.
+func ConstantBackoff(d time.Duration) BackoffStrategy {
+	return constantBackoff{delay: d}
+}
+
+func (b constantBackoff) NextDelay(attempt int, lastDelay time.Duration, err error) time.Duration {
+	return b.delay
+}
+
+// linearBackoff grows the delay linearly with the attempt number.
+type linearBackoff struct {
+	step time.Duration
+}
+
+// LinearBackoff returns a BackoffStrategy that waits attempt*step before
+// the next attempt.
+func LinearBackoff(step time.Duration) BackoffStrategy {
+	return linearBackoff{step: step}
+}
+
+func (b linearBackoff) NextDelay(attempt int, lastDelay time.Duration, err error) time.Duration {
+	return time.Duration(attempt) * b.step
+}
+
+// ExponentialBackoff grows the delay as Base*Factor^(attempt-1), capped at Max.
+type ExponentialBackoff struct {
+	Base   time.Duration
+	Factor float64
+	Max    time.Duration
+}
+
+func (b ExponentialBackoff) NextDelay(attempt int, lastDelay time.Duration, err error) time.Duration {
+	factor := b.Factor
+	if factor <= 0 {
+		factor = 2.0
+	}
+	d := time.Duration(float64(b.Base) * math.Pow(factor, float64(attempt-1)))
+	if b.Max > 0 && d > b.Max {
+		d = b.Max
+	}
+	return d
+}
+
+// FibonacciBackoff grows the delay as Base times the Fibonacci sequence,
+// capped at Max.
+type FibonacciBackoff struct {
+	Base time.Duration
+	Max  time.Duration
+}
+
+func (b FibonacciBackoff) NextDelay(attempt int, lastDelay time.Duration, err error) time.Duration {
+	a, next := 1, 1
+	for i := 1; i < attempt; i++ {
+		a, next = next, a+next
+	}
+	d := time.Duration(a) * b.Base
+	if b.Max > 0 && d > b.Max {
+		d = b.Max
+	}
+	return d
+}
@@ -0,0 +1,93 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestConstantBackoffNeverChanges checks that ConstantBackoff returns the
+// same delay regardless of attempt number.
+func TestConstantBackoffNeverChanges(t *testing.T) {
+	b := ConstantBackoff(10 * time.Millisecond)
+	for attempt := 1; attempt <= 5; attempt++ {
+		if got := b.NextDelay(attempt, 0, nil); got != 10*time.Millisecond {
+			t.Errorf("attempt %d: expected 10ms, got %v", attempt, got)
+		}
+	}
+}
+
+// TestLinearBackoffGrowsByStep checks that LinearBackoff scales with the
+// attempt number.
+func TestLinearBackoffGrowsByStep(t *testing.T) {
+	b := LinearBackoff(5 * time.Millisecond)
+	want := []time.Duration{5 * time.Millisecond, 10 * time.Millisecond, 15 * time.Millisecond}
+	for i, w := range want {
+		attempt := i + 1
+		if got := b.NextDelay(attempt, 0, nil); got != w {
+			t.Errorf("attempt %d: expected %v, got %v", attempt, w, got)
+		}
+	}
+}
+
+// TestExponentialBackoffCapsAtMax checks that ExponentialBackoff grows by
+// Factor and respects Max.
+func TestExponentialBackoffCapsAtMax(t *testing.T) {
+	b := ExponentialBackoff{Base: 5 * time.Millisecond, Factor: 2.0, Max: 20 * time.Millisecond}
+	want := []time.Duration{5 * time.Millisecond, 10 * time.Millisecond, 20 * time.Millisecond, 20 * time.Millisecond}
+	for i, w := range want {
+		attempt := i + 1
+		if got := b.NextDelay(attempt, 0, nil); got != w {
+			t.Errorf("attempt %d: expected %v, got %v", attempt, w, got)
+		}
+	}
+}
+
+// TestFibonacciBackoffFollowsSequence checks that FibonacciBackoff scales
+// delays by the Fibonacci sequence and respects Max.
+func TestFibonacciBackoffFollowsSequence(t *testing.T) {
+	b := FibonacciBackoff{Base: 5 * time.Millisecond, Max: 30 * time.Millisecond}
+	// Fibonacci: 1, 1, 2, 3, 5, 8 -> 5ms, 5ms, 10ms, 15ms, 25ms, 30ms(capped 40ms)
+	want := []time.Duration{5 * time.Millisecond, 5 * time.Millisecond, 10 * time.Millisecond, 15 * time.Millisecond, 25 * time.Millisecond, 30 * time.Millisecond}
+	for i, w := range want {
+		attempt := i + 1
+		if got := b.NextDelay(attempt, 0, nil); got != w {
+			t.Errorf("attempt %d: expected %v, got %v", attempt, w, got)
+		}
+	}
+}
+
+// TestDoUsesBackoffStrategyWhenSet checks that Do defers to Config.Backoff
+// instead of the Delay/Factor/Jitter fields once it is set.
+func TestDoUsesBackoffStrategyWhenSet(t *testing.T) {
+	attemptsMade := 0
+	op := func(ctx context.Context) error {
+		attemptsMade++
+		return errors.New("persistent simulated failure")
+	}
+
+	var delays []time.Duration
+	cfg := Config{
+		Attempts: 3,
+		Backoff:  ConstantBackoff(1 * time.Millisecond),
+		OnRetry:  func(a Attempt) { delays = append(delays, a.NextDelay) },
+	}
+
+	err := Do(context.Background(), cfg, op)
+	if err == nil {
+		t.Fatal("Expected an error, but got nil")
+	}
+	if attemptsMade != cfg.Attempts {
+		t.Errorf("Expected %d attempts, got %d", cfg.Attempts, attemptsMade)
+	}
+	want := []time.Duration{1 * time.Millisecond, 1 * time.Millisecond}
+	if len(delays) != len(want) {
+		t.Fatalf("Expected delays %v, got %v", want, delays)
+	}
+	for i, w := range want {
+		if delays[i] != w {
+			t.Errorf("Delay %d: expected %v, got %v", i, w, delays[i])
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package retry
+
+import "time"
+
+// Clock abstracts time so that Do and DoWithData can be driven by a fake
+// clock in tests instead of sleeping for real.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is the default Clock, backed by the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
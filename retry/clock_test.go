@@ -0,0 +1,57 @@
+package retry
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"retry-example/retry/retrytest"
+)
+
+// TestFakeClockDrivesExactDelaySequence checks that Do asks the Clock for
+// exactly the expected delay sequence (5ms -> 10ms -> 20ms capped), without
+// any real sleeping.
+func TestFakeClockDrivesExactDelaySequence(t *testing.T) {
+	clock := retrytest.NewFakeClock(time.Unix(0, 0))
+	attemptsMade := 0
+	op := func(ctx context.Context) error {
+		attemptsMade++
+		return fmt.Errorf("failure %d", attemptsMade)
+	}
+
+	cfg := Config{
+		Attempts: 4,
+		Delay:    5 * time.Millisecond,
+		MaxDelay: 20 * time.Millisecond,
+		Factor:   2.0,
+		Jitter:   JitterNone,
+		Clock:    clock,
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- Do(context.Background(), cfg, op)
+	}()
+
+	for i := 0; i < 3; i++ {
+		<-clock.Notify()
+		clock.Advance(time.Hour)
+	}
+
+	err := <-done
+	if err == nil {
+		t.Error("Expected error after all attempts, got nil")
+	}
+
+	want := []time.Duration{5 * time.Millisecond, 10 * time.Millisecond, 20 * time.Millisecond}
+	got := clock.Delays()
+	if len(got) != len(want) {
+		t.Fatalf("Expected delays %v, got %v", want, got)
+	}
+	for i, w := range want {
+		if got[i] != w {
+			t.Errorf("Delay %d: expected %v, got %v", i, w, got[i])
+		}
+	}
+}
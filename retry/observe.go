@@ -0,0 +1,20 @@
+package retry
+
+import "time"
+
+// Attempt describes the state of a single retry attempt, passed to the
+// OnRetry, OnError and OnSuccess callbacks.
+type Attempt struct {
+	// N is the 1-based attempt number this callback relates to.
+	N int
+	// Elapsed is the time since Do/DoWithData started.
+	Elapsed time.Duration
+	// NextDelay is the delay before the next attempt. It is zero for
+	// OnError and OnSuccess, which have no next attempt.
+	NextDelay time.Duration
+	// Err is the error from this attempt, or nil on success.
+	Err error
+	// LastErr is the error from the previous attempt, or nil if this is
+	// the first failure seen.
+	LastErr error
+}
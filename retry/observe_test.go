@@ -0,0 +1,121 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestCallbacksFireInOrderOnEventualSuccess checks that OnRetry fires once
+// per failed attempt, in order, and OnSuccess fires once at the end, with
+// no OnError.
+func TestCallbacksFireInOrderOnEventualSuccess(t *testing.T) {
+	var events []string
+	attemptsMade := 0
+	op := func(ctx context.Context) error {
+		attemptsMade++
+		if attemptsMade < 3 {
+			return fmt.Errorf("simulated failure %d", attemptsMade)
+		}
+		return nil
+	}
+
+	cfg := DefaultConfig()
+	cfg.Attempts = 3
+	cfg.Delay = 1 * time.Millisecond
+	cfg.OnRetry = func(a Attempt) { events = append(events, fmt.Sprintf("retry:%d", a.N)) }
+	cfg.OnError = func(a Attempt) { events = append(events, fmt.Sprintf("error:%d", a.N)) }
+	cfg.OnSuccess = func(a Attempt) { events = append(events, fmt.Sprintf("success:%d", a.N)) }
+
+	if err := Do(context.Background(), cfg, op); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	want := []string{"retry:1", "retry:2", "success:3"}
+	if len(events) != len(want) {
+		t.Fatalf("Expected events %v, got %v", want, events)
+	}
+	for i, w := range want {
+		if events[i] != w {
+			t.Errorf("Expected event %d to be %q, got %q", i, w, events[i])
+		}
+	}
+}
+
+// TestCallbacksFireOnExhaustedAttempts checks that OnRetry fires for every
+// failure but the last, and OnError fires exactly once at the end.
+func TestCallbacksFireOnExhaustedAttempts(t *testing.T) {
+	var events []string
+	attemptsMade := 0
+	op := func(ctx context.Context) error {
+		attemptsMade++
+		return fmt.Errorf("persistent simulated failure %d", attemptsMade)
+	}
+
+	cfg := DefaultConfig()
+	cfg.Attempts = 3
+	cfg.Delay = 1 * time.Millisecond
+	cfg.OnRetry = func(a Attempt) { events = append(events, fmt.Sprintf("retry:%d", a.N)) }
+	cfg.OnError = func(a Attempt) { events = append(events, fmt.Sprintf("error:%d", a.N)) }
+	cfg.OnSuccess = func(a Attempt) { events = append(events, "success") }
+
+	if err := Do(context.Background(), cfg, op); err == nil {
+		t.Fatal("Expected an error, but got nil")
+	}
+
+	want := []string{"retry:1", "retry:2", "error:3"}
+	if len(events) != len(want) {
+		t.Fatalf("Expected events %v, got %v", want, events)
+	}
+	for i, w := range want {
+		if events[i] != w {
+			t.Errorf("Expected event %d to be %q, got %q", i, w, events[i])
+		}
+	}
+}
+
+// TestOnErrorLastErrIsPreviousAttemptOnExhaustion checks that the final
+// OnError call, fired once all attempts are exhausted, reports LastErr as
+// the error from the second-to-last attempt, not the same error as Err.
+func TestOnErrorLastErrIsPreviousAttemptOnExhaustion(t *testing.T) {
+	attemptsMade := 0
+	op := func(ctx context.Context) error {
+		attemptsMade++
+		return fmt.Errorf("err-%d", attemptsMade)
+	}
+
+	var got Attempt
+	cfg := DefaultConfig()
+	cfg.Attempts = 3
+	cfg.Delay = 1 * time.Millisecond
+	cfg.OnError = func(a Attempt) { got = a }
+
+	if err := Do(context.Background(), cfg, op); err == nil {
+		t.Fatal("Expected an error, but got nil")
+	}
+
+	if got.Err.Error() != "err-3" {
+		t.Errorf("Expected Err to be %q, got %q", "err-3", got.Err)
+	}
+	if got.LastErr.Error() != "err-2" {
+		t.Errorf("Expected LastErr to be %q, got %q", "err-2", got.LastErr)
+	}
+}
+
+// TestNilCallbacksAreSafe checks that leaving the callbacks unset doesn't
+// panic.
+func TestNilCallbacksAreSafe(t *testing.T) {
+	op := func(ctx context.Context) error {
+		return errors.New("simulated failure")
+	}
+
+	cfg := DefaultConfig()
+	cfg.Attempts = 2
+	cfg.Delay = 1 * time.Millisecond
+
+	if err := Do(context.Background(), cfg, op); err == nil {
+		t.Fatal("Expected an error, but got nil")
+	}
+}
@@ -2,7 +2,8 @@ package retry
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"math/rand"
 	"time"
 )
 
@@ -10,6 +11,24 @@ import (
 // It should return nil if successful, or an error if it fails.
 type Operation func(ctx context.Context) error
 
+// JitterMode selects how randomization is applied to a computed backoff
+// delay before the retry loop sleeps on it.
+type JitterMode int
+
+const (
+	// JitterNone uses the computed delay as-is, with no randomization.
+	JitterNone JitterMode = iota
+	// JitterFull samples uniformly from [0, cap], per the AWS "exponential
+	// backoff and jitter" recommendation. This is the default.
+	JitterFull
+	// JitterEqual samples uniformly from [cap/2, cap], trading some
+	// spread for a higher minimum delay than JitterFull.
+	JitterEqual
+	// JitterDecorrelated grows the delay off the previous sleep rather than
+	// the attempt count, which further decorrelates retries across clients.
+	JitterDecorrelated
+)
+
 // Config holds the retry configuration
 type Config struct {
 	// Number of attempts to make (including the first try)
@@ -20,10 +39,31 @@ type Config struct {
 	MaxDelay time.Duration
 	// Multiplier for delay after each attempt
 	Factor float64
-	// Whether to add random jitter to delays
-	Jitter bool
+	// Jitter selects how randomization is applied to each computed delay.
+	// The zero value is JitterNone; DefaultConfig sets JitterFull.
+	Jitter JitterMode
+	// Rng supplies randomness for Jitter. If nil, the math/rand package-level
+	// source is used. Set this to a seeded *rand.Rand for deterministic tests.
+	Rng *rand.Rand
+	// Backoff, when set, computes the delay before each retry and takes
+	// over from MaxDelay, Factor and Jitter; Delay is only passed to it as
+	// lastDelay for the first attempt.
+	Backoff BackoffStrategy
 	// Function to determine if an error is retryable
 	IsRetryable func(error) bool
+	// OnRetry, if set, is called after an attempt fails and before Do
+	// sleeps ahead of the next attempt.
+	OnRetry func(Attempt)
+	// OnError, if set, is called once Do gives up and returns an error,
+	// whether because attempts were exhausted or the error wasn't retryable.
+	// It is not called when Do returns because ctx is done.
+	OnError func(Attempt)
+	// OnSuccess, if set, is called once an attempt succeeds.
+	OnSuccess func(Attempt)
+	// Clock supplies time for delays and Attempt.Elapsed. If nil, the real
+	// system clock is used. Set this to a retrytest.FakeClock for
+	// deterministic tests.
+	Clock Clock
 }
 
 // DefaultConfig returns a Config with sensible defaults
@@ -33,36 +73,102 @@ func DefaultConfig() Config {
 		Delay:       100 * time.Millisecond,
 		MaxDelay:    1 * time.Second,
 		Factor:      2.0,
-		Jitter:      true,
+		Jitter:      JitterFull,
 		IsRetryable: func(err error) bool { return true },
 	}
 }
 
+// jitterInt63n returns a random int64 in [0, n) using cfg.Rng if set, or the
+// math/rand package-level source otherwise. It returns 0 for n <= 0.
+func jitterInt63n(cfg *Config, n int64) int64 {
+	if n <= 0 {
+		return 0
+	}
+	if cfg.Rng != nil {
+		return cfg.Rng.Int63n(n)
+	}
+	return rand.Int63n(n)
+}
+
+// jitteredSleep applies cfg.Jitter to capDelay, the deterministic delay for
+// the current attempt, using prevSleep as the basis for JitterDecorrelated.
+func jitteredSleep(cfg *Config, capDelay, prevSleep time.Duration) time.Duration {
+	switch cfg.Jitter {
+	case JitterFull:
+		return time.Duration(jitterInt63n(cfg, int64(capDelay)+1))
+	case JitterEqual:
+		half := int64(capDelay) / 2
+		return time.Duration(half + jitterInt63n(cfg, half+1))
+	case JitterDecorrelated:
+		upper := 3*int64(prevSleep) - int64(cfg.Delay)
+		if upper <= 0 {
+			upper = int64(cfg.Delay)
+		}
+		sleep := jitterInt63n(cfg, upper) + int64(cfg.Delay)
+		if cfg.MaxDelay > 0 && sleep > int64(cfg.MaxDelay) {
+			sleep = int64(cfg.MaxDelay)
+		}
+		return time.Duration(sleep)
+	default: // JitterNone
+		return capDelay
+	}
+}
+
 // Do executes the operation with retries according to the config
 func Do(ctx context.Context, cfg Config, op func(context.Context) error) error {
+	_, err := DoWithData(ctx, cfg, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, op(ctx)
+	})
+	return err
+}
+
+// DoWithData executes the operation with retries according to the config,
+// returning the operation's result on success. On ultimate failure it
+// returns the zero value of T alongside the error.
+func DoWithData[T any](ctx context.Context, cfg Config, op func(context.Context) (T, error)) (T, error) {
+	var zero T
 	var lastErr error
+	var finalPrevErr error
+	var errs []error
+	if cfg.Attempts > 0 {
+		errs = make([]error, 0, cfg.Attempts)
+	}
 	if cfg.IsRetryable == nil {
 		cfg.IsRetryable = func(err error) bool { return true }
 	}
+	if cfg.Clock == nil {
+		cfg.Clock = realClock{}
+	}
 	delay := cfg.Delay
+	prevSleep := cfg.Delay
+	start := cfg.Clock.Now()
 
 	for attempt := 1; attempt <= cfg.Attempts; attempt++ {
 		// Check context before each attempt
 		if err := ctx.Err(); err != nil {
-			return err
+			return zero, err
 		}
 
 		// Execute the operation
-		err := op(ctx)
+		result, err := op(ctx)
 		if err == nil {
-			return nil // Success!
+			if cfg.OnSuccess != nil {
+				cfg.OnSuccess(Attempt{N: attempt, Elapsed: cfg.Clock.Now().Sub(start), LastErr: lastErr})
+			}
+			return result, nil // Success!
 		}
 
+		prevErr := lastErr
 		lastErr = err
+		finalPrevErr = prevErr
+		errs = append(errs, err)
 
 		// Check if error is retryable
 		if !cfg.IsRetryable(err) {
-			return err
+			if cfg.OnError != nil {
+				cfg.OnError(Attempt{N: attempt, Elapsed: cfg.Clock.Now().Sub(start), Err: err, LastErr: prevErr})
+			}
+			return zero, err
 		}
 
 		// If this was the last attempt, don't wait
@@ -70,24 +176,54 @@ func Do(ctx context.Context, cfg Config, op func(context.Context) error) error {
 			break
 		}
 
-		// Calculate next delay
-		nextDelay := delay
-		if cfg.Factor > 0 {
-			nextDelay = time.Duration(float64(delay) * cfg.Factor)
+		// Calculate the delay to sleep before the next attempt
+		var sleep time.Duration
+		if cfg.Backoff != nil {
+			sleep = cfg.Backoff.NextDelay(attempt, delay, err)
+			delay = sleep
+		} else {
+			nextDelay := delay
+			if cfg.Factor > 0 {
+				nextDelay = time.Duration(float64(delay) * cfg.Factor)
+			}
+			if cfg.MaxDelay > 0 && nextDelay > cfg.MaxDelay {
+				nextDelay = cfg.MaxDelay
+			}
+			sleep = jitteredSleep(&cfg, delay, prevSleep)
+			delay = nextDelay
 		}
-		if cfg.MaxDelay > 0 && nextDelay > cfg.MaxDelay {
-			nextDelay = cfg.MaxDelay
+
+		// Honor a server-provided Retry-After hint as a floor, capped by
+		// whatever time remains before the context deadline.
+		var ra RetryAfter
+		if errors.As(err, &ra) {
+			if hint := ra.RetryAfter(); hint > sleep {
+				sleep = hint
+			}
+		}
+		if deadline, ok := ctx.Deadline(); ok {
+			if remaining := time.Until(deadline); remaining < sleep {
+				sleep = remaining
+			}
+		}
+
+		if cfg.OnRetry != nil {
+			cfg.OnRetry(Attempt{N: attempt, Elapsed: cfg.Clock.Now().Sub(start), NextDelay: sleep, Err: err, LastErr: prevErr})
 		}
 
 		// Wait for next attempt
 		select {
-		case <-time.After(delay):
+		case <-cfg.Clock.After(sleep):
 		case <-ctx.Done():
-			return ctx.Err()
+			return zero, ctx.Err()
 		}
 
-		delay = nextDelay
+		prevSleep = sleep
+	}
+
+	if cfg.OnError != nil {
+		cfg.OnError(Attempt{N: cfg.Attempts, Elapsed: cfg.Clock.Now().Sub(start), Err: lastErr, LastErr: finalPrevErr})
 	}
 
-	return fmt.Errorf("operation failed after %d attempts: %v", cfg.Attempts, lastErr)
+	return zero, &RetryError{Attempts: cfg.Attempts, Errors: errs}
 }
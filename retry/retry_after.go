@@ -0,0 +1,31 @@
+package retry
+
+import "time"
+
+// RetryAfter is implemented by errors that carry a server-provided hint for
+// how long to wait before retrying, e.g. an HTTP 429 response's Retry-After
+// header or a gRPC RESOURCE_EXHAUSTED hint. When an operation's error
+// implements RetryAfter, Do and DoWithData use it as a floor for the next
+// sleep, which prevents many clients from retrying a throttled dependency
+// at exactly the same moment.
+type RetryAfter interface {
+	RetryAfter() time.Duration
+}
+
+// retryAfterError wraps an error with a RetryAfter hint.
+type retryAfterError struct {
+	err error
+	d   time.Duration
+}
+
+// WithRetryAfter wraps err so that callers checking RetryAfter (including
+// Do and DoWithData) see d as the server-provided retry hint.
+func WithRetryAfter(err error, d time.Duration) error {
+	return &retryAfterError{err: err, d: d}
+}
+
+func (e *retryAfterError) Error() string { return e.err.Error() }
+
+func (e *retryAfterError) Unwrap() error { return e.err }
+
+func (e *retryAfterError) RetryAfter() time.Duration { return e.d }
@@ -0,0 +1,58 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestRetryAfterFloorsTheNextSleep checks that a RetryAfter hint on the
+// operation's error delays the next attempt by at least the hinted duration.
+func TestRetryAfterFloorsTheNextSleep(t *testing.T) {
+	attemptsMade := 0
+	hint := 30 * time.Millisecond
+	op := func(ctx context.Context) error {
+		attemptsMade++
+		if attemptsMade == 1 {
+			return WithRetryAfter(errors.New("throttled"), hint)
+		}
+		return nil
+	}
+
+	cfg := Config{Attempts: 2, Delay: 1 * time.Millisecond, Jitter: JitterNone}
+
+	start := time.Now()
+	err := Do(context.Background(), cfg, op)
+	elapsed := time.Since(start)
+
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if elapsed < hint {
+		t.Errorf("Expected to wait at least %v before retrying, waited %v", hint, elapsed)
+	}
+}
+
+// TestRetryAfterCappedByContextDeadline checks that a RetryAfter hint
+// longer than the remaining context deadline doesn't outlive the context.
+func TestRetryAfterCappedByContextDeadline(t *testing.T) {
+	op := func(ctx context.Context) error {
+		return WithRetryAfter(errors.New("throttled"), 1*time.Hour)
+	}
+
+	cfg := Config{Attempts: 2, Delay: 1 * time.Millisecond, Jitter: JitterNone}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := Do(ctx, cfg, op)
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("Expected an error due to context deadline, but got nil")
+	}
+	if elapsed > 500*time.Millisecond {
+		t.Errorf("Expected the wait to be capped by the context deadline, took %v", elapsed)
+	}
+}
@@ -0,0 +1,25 @@
+package retry
+
+import "fmt"
+
+// RetryError is returned by Do and DoWithData once all attempts are
+// exhausted. It preserves every attempt's error, not just the last, so
+// callers can still use errors.Is/As against an error from an earlier
+// attempt.
+type RetryError struct {
+	Attempts int
+	Errors   []error
+}
+
+func (e *RetryError) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("operation failed after %d attempts", e.Attempts)
+	}
+	return fmt.Sprintf("operation failed after %d attempts: %v", e.Attempts, e.Errors[len(e.Errors)-1])
+}
+
+// Unwrap exposes every attempt's error to errors.Is/As (Go 1.20 multi-unwrap),
+// which already recurses into each one without needing a custom Is method.
+func (e *RetryError) Unwrap() []error {
+	return e.Errors
+}
@@ -0,0 +1,71 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestRetryErrorPreservesEveryAttempt checks that errors.Is matches an error
+// from an earlier attempt, not just the last one.
+func TestRetryErrorPreservesEveryAttempt(t *testing.T) {
+	ErrFirst := errors.New("first attempt failure")
+	attemptsMade := 0
+	op := func(ctx context.Context) error {
+		attemptsMade++
+		if attemptsMade == 1 {
+			return ErrFirst
+		}
+		return errors.New("later attempt failure")
+	}
+
+	cfg := DefaultConfig()
+	cfg.Attempts = 3
+	cfg.Delay = 1 * time.Millisecond
+
+	err := Do(context.Background(), cfg, op)
+	if err == nil {
+		t.Fatal("Expected an error, but got nil")
+	}
+	if !errors.Is(err, ErrFirst) {
+		t.Errorf("Expected errors.Is to match the first attempt's error, got %v", err)
+	}
+
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) {
+		t.Fatalf("Expected errors.As to find a *RetryError, got %T", err)
+	}
+	if retryErr.Attempts != cfg.Attempts {
+		t.Errorf("Expected Attempts %d, got %d", cfg.Attempts, retryErr.Attempts)
+	}
+	if len(retryErr.Errors) != cfg.Attempts {
+		t.Errorf("Expected %d preserved errors, got %d", cfg.Attempts, len(retryErr.Errors))
+	}
+}
+
+// TestNonPositiveAttemptsDoesNotPanic checks that a negative or zero
+// Attempts count is handled like before: the loop is simply skipped,
+// rather than panicking while preallocating the error slice.
+func TestNonPositiveAttemptsDoesNotPanic(t *testing.T) {
+	op := func(ctx context.Context) error {
+		t.Fatal("op should not be called when Attempts <= 0")
+		return nil
+	}
+
+	cfg := DefaultConfig()
+	cfg.Attempts = -1
+
+	err := Do(context.Background(), cfg, op)
+	if err == nil {
+		t.Fatal("Expected an error, but got nil")
+	}
+
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) {
+		t.Fatalf("Expected errors.As to find a *RetryError, got %T", err)
+	}
+	if len(retryErr.Errors) != 0 {
+		t.Errorf("Expected no preserved errors, got %d", len(retryErr.Errors))
+	}
+}
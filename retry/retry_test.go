@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
 	"strings"
 	"testing"
 	"time"
@@ -150,6 +151,126 @@ func TestNonRetryableError(t *testing.T) {
 	}
 }
 
+// TestDoWithDataReturnsValueOnSuccess checks that DoWithData propagates the
+// operation's result once it succeeds.
+func TestDoWithDataReturnsValueOnSuccess(t *testing.T) {
+	attemptsMade := 0
+	op := func(ctx context.Context) (string, error) {
+		attemptsMade++
+		if attemptsMade < 2 {
+			return "", fmt.Errorf("simulated failure %d", attemptsMade)
+		}
+		return "payload", nil
+	}
+
+	cfg := DefaultConfig()
+	cfg.Attempts = 3
+	cfg.Delay = 1 * time.Millisecond
+
+	result, err := DoWithData(context.Background(), cfg, op)
+	if err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	if result != "payload" {
+		t.Errorf("Expected result %q, got %q", "payload", result)
+	}
+}
+
+// TestDoWithDataReturnsZeroValueOnFailure checks that DoWithData returns the
+// zero value of T once all attempts are exhausted.
+func TestDoWithDataReturnsZeroValueOnFailure(t *testing.T) {
+	op := func(ctx context.Context) (int, error) {
+		return 0, errors.New("persistent simulated failure")
+	}
+
+	cfg := DefaultConfig()
+	cfg.Attempts = 2
+	cfg.Delay = 1 * time.Millisecond
+
+	result, err := DoWithData(context.Background(), cfg, op)
+	if err == nil {
+		t.Fatal("Expected an error, but got nil")
+	}
+	if result != 0 {
+		t.Errorf("Expected zero value 0, got %d", result)
+	}
+}
+
+// TestJitterFullStaysWithinCap checks that a Do run with JitterFull finishes
+// within three MaxDelay-capped sleeps plus some slack.
+func TestJitterFullStaysWithinCap(t *testing.T) {
+	attemptsMade := 0
+	op := func(ctx context.Context) error {
+		attemptsMade++
+		return fmt.Errorf("failure %d", attemptsMade)
+	}
+
+	cfg := Config{
+		Attempts: 4,
+		Delay:    5 * time.Millisecond,
+		MaxDelay: 20 * time.Millisecond,
+		Factor:   2.0,
+		Jitter:   JitterFull,
+		Rng:      rand.New(rand.NewSource(1)),
+	}
+
+	start := time.Now()
+	err := Do(context.Background(), cfg, op)
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Error("Expected error after all attempts, got nil")
+	}
+	// Upper bound: three sleeps, each capped at MaxDelay, plus slack.
+	if elapsed > 3*cfg.MaxDelay+50*time.Millisecond {
+		t.Errorf("Expected jittered sleeps to stay near the caps, took %v", elapsed)
+	}
+}
+
+// TestJitterNoneIsDeterministic checks that JitterNone returns the computed
+// delay unchanged, with no randomization.
+func TestJitterNoneIsDeterministic(t *testing.T) {
+	cfg := Config{Delay: 5 * time.Millisecond, MaxDelay: 20 * time.Millisecond, Jitter: JitterNone}
+
+	got := jitteredSleep(&cfg, 10*time.Millisecond, 5*time.Millisecond)
+	if got != 10*time.Millisecond {
+		t.Errorf("Expected JitterNone to pass the cap through unchanged, got %v", got)
+	}
+}
+
+// TestJitterEqualStaysWithinBounds checks that JitterEqual samples within
+// [cap/2, cap].
+func TestJitterEqualStaysWithinBounds(t *testing.T) {
+	cfg := Config{Delay: 5 * time.Millisecond, Jitter: JitterEqual, Rng: rand.New(rand.NewSource(42))}
+	capDelay := 20 * time.Millisecond
+
+	for i := 0; i < 100; i++ {
+		got := jitteredSleep(&cfg, capDelay, capDelay)
+		if got < capDelay/2 || got > capDelay {
+			t.Fatalf("Expected delay within [%v, %v], got %v", capDelay/2, capDelay, got)
+		}
+	}
+}
+
+// TestJitterDecorrelatedRespectsMaxDelay checks that JitterDecorrelated
+// never exceeds MaxDelay even as prevSleep grows.
+func TestJitterDecorrelatedRespectsMaxDelay(t *testing.T) {
+	cfg := Config{
+		Delay:    5 * time.Millisecond,
+		MaxDelay: 20 * time.Millisecond,
+		Jitter:   JitterDecorrelated,
+		Rng:      rand.New(rand.NewSource(7)),
+	}
+
+	prevSleep := cfg.Delay
+	for i := 0; i < 50; i++ {
+		prevSleep = jitteredSleep(&cfg, 0, prevSleep)
+		if prevSleep > cfg.MaxDelay {
+			t.Fatalf("Expected sleep capped at %v, got %v", cfg.MaxDelay, prevSleep)
+		}
+	}
+}
+
 // TestExponentialBackoffAndMaxDelay checks if delays increase and respect MaxDelay
 func TestExponentialBackoffAndMaxDelay(t *testing.T) {
 	// This test is more about observing logs than strict pass/fail on timing in a unit test.
@@ -167,7 +288,7 @@ func TestExponentialBackoffAndMaxDelay(t *testing.T) {
 		Delay:    5 * time.Millisecond,
 		MaxDelay: 20 * time.Millisecond,
 		Factor:   2.0,
-		Jitter:   false, // Disable jitter for predictable delay calculation in logs
+		Jitter:   JitterNone, // Disable jitter for predictable delay calculation in logs
 	}
 
 	err := Do(context.Background(), cfg, op)
@@ -0,0 +1,91 @@
+// Package retrytest provides test doubles for retry.Clock so backoff and
+// jitter behavior can be asserted deterministically, without real sleeping.
+package retrytest
+
+import (
+	"sync"
+	"time"
+)
+
+// FakeClock is a virtual clock implementing retry.Clock. Time only advances
+// when Advance is called, and pending After channels fire in Advance once
+// the virtual clock reaches their deadline.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+	delays  []time.Duration
+	notify  chan struct{}
+}
+
+type fakeWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+// NewFakeClock returns a FakeClock starting at start.
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start, notify: make(chan struct{}, 1024)}
+}
+
+// Now returns the current virtual time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After records the requested delay and returns a channel that fires once
+// Advance moves the virtual clock past it.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.delays = append(c.delays, d)
+	select {
+	case c.notify <- struct{}{}:
+	default:
+	}
+
+	ch := make(chan time.Time, 1)
+	deadline := c.now.Add(d)
+	if d <= 0 {
+		ch <- deadline
+		return ch
+	}
+	c.waiters = append(c.waiters, fakeWaiter{deadline: deadline, ch: ch})
+	return ch
+}
+
+// Notify returns a channel that receives a value every time After is
+// called, so a test can synchronize Advance with a retry loop running on
+// another goroutine instead of polling.
+func (c *FakeClock) Notify() <-chan struct{} {
+	return c.notify
+}
+
+// Advance moves the virtual clock forward by d, firing any pending After
+// channels whose deadline has been reached.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+
+	remaining := c.waiters[:0]
+	for _, w := range c.waiters {
+		if !w.deadline.After(c.now) {
+			w.ch <- w.deadline
+		} else {
+			remaining = append(remaining, w)
+		}
+	}
+	c.waiters = remaining
+}
+
+// Delays returns the durations passed to After, in call order.
+func (c *FakeClock) Delays() []time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]time.Duration, len(c.delays))
+	copy(out, c.delays)
+	return out
+}